@@ -0,0 +1,26 @@
+package main
+
+import "sync"
+
+// configHolder lets the running service pick up a reloaded Config (e.g. on
+// SIGHUP) without restarting workers that are already mid-sync.
+type configHolder struct {
+	mu  sync.RWMutex
+	cfg *Config
+}
+
+func newConfigHolder(cfg *Config) *configHolder {
+	return &configHolder{cfg: cfg}
+}
+
+func (h *configHolder) Get() *Config {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.cfg
+}
+
+func (h *configHolder) Set(cfg *Config) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cfg = cfg
+}
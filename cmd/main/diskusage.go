@@ -0,0 +1,28 @@
+package main
+
+import "os"
+
+// dirSize walks path and sums the size of every regular file in it. It is
+// used as an approximation of "bytes fetched" for the bytes_fetched_total
+// metric, since go-git doesn't expose transfer byte counts directly.
+func dirSize(path string) int64 {
+	var size int64
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return 0
+	}
+
+	for _, entry := range entries {
+		full := path + string(os.PathSeparator) + entry.Name()
+		if entry.IsDir() {
+			size += dirSize(full)
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		size += info.Size()
+	}
+	return size
+}
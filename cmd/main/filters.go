@@ -0,0 +1,45 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/hightemp/github_repo_sync/internal/remote"
+)
+
+// matchesAnyPattern reports whether fullName (or its bare repo name) matches
+// any of the given glob patterns. Patterns support the same syntax as
+// filepath.Match, e.g. "org/*" or "*-archive".
+func matchesAnyPattern(patterns []string, fullName, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, fullName); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldIncludeRepo applies include/exclude rules to repo and reports
+// whether it should be synced. includeRepos/excludeRepos come from the
+// owning remote's own config; includeForks/includeArchived are global.
+// Exclusion rules always win over inclusion rules.
+func shouldIncludeRepo(repo *remote.RemoteRepo, includeRepos, excludeRepos []string, includeForks, includeArchived bool) bool {
+	if !includeForks && repo.IsFork {
+		return false
+	}
+	if !includeArchived && repo.IsArchived {
+		return false
+	}
+
+	if len(excludeRepos) > 0 && matchesAnyPattern(excludeRepos, repo.FullName, repo.Name) {
+		return false
+	}
+
+	if len(includeRepos) > 0 {
+		return matchesAnyPattern(includeRepos, repo.FullName, repo.Name)
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hightemp/github_repo_sync/internal/remote"
+)
+
+func TestMatchesAnyPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		fullName string
+		repoName string
+		want     bool
+	}{
+		{"empty patterns", nil, "acme/widgets", "widgets", false},
+		{"matches full name glob", []string{"acme/*"}, "acme/widgets", "widgets", true},
+		{"matches bare name glob", []string{"*-archive"}, "acme/widgets-archive", "widgets-archive", true},
+		{"no match", []string{"other/*"}, "acme/widgets", "widgets", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAnyPattern(tt.patterns, tt.fullName, tt.repoName); got != tt.want {
+				t.Errorf("matchesAnyPattern(%v, %q, %q) = %v, want %v",
+					tt.patterns, tt.fullName, tt.repoName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldIncludeRepo(t *testing.T) {
+	tests := []struct {
+		name            string
+		repo            remote.RemoteRepo
+		includeRepos    []string
+		excludeRepos    []string
+		includeForks    bool
+		includeArchived bool
+		want            bool
+	}{
+		{
+			name: "forks excluded by default",
+			repo: remote.RemoteRepo{FullName: "acme/widgets", IsFork: true},
+			want: false,
+		},
+		{
+			name:         "forks included when enabled",
+			repo:         remote.RemoteRepo{FullName: "acme/widgets", IsFork: true},
+			includeForks: true,
+			want:         true,
+		},
+		{
+			name: "archived excluded by default",
+			repo: remote.RemoteRepo{FullName: "acme/widgets", IsArchived: true},
+			want: false,
+		},
+		{
+			name:            "archived included when enabled",
+			repo:            remote.RemoteRepo{FullName: "acme/widgets", IsArchived: true},
+			includeArchived: true,
+			want:            true,
+		},
+		{
+			name:         "exclude wins over include",
+			repo:         remote.RemoteRepo{FullName: "acme/widgets", Name: "widgets"},
+			includeRepos: []string{"acme/*"},
+			excludeRepos: []string{"acme/widgets"},
+			want:         false,
+		},
+		{
+			name:         "include list filters non-matches",
+			repo:         remote.RemoteRepo{FullName: "acme/other", Name: "other"},
+			includeRepos: []string{"acme/widgets"},
+			want:         false,
+		},
+		{
+			name: "no include list means everything passes",
+			repo: remote.RemoteRepo{FullName: "acme/widgets", Name: "widgets"},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shouldIncludeRepo(&tt.repo, tt.includeRepos, tt.excludeRepos, tt.includeForks, tt.includeArchived)
+			if got != tt.want {
+				t.Errorf("shouldIncludeRepo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
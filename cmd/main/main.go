@@ -6,40 +6,70 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
-	"github.com/google/go-github/v45/github"
-	"golang.org/x/oauth2"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"gopkg.in/yaml.v2"
+
+	"github.com/hightemp/github_repo_sync/internal/graceful"
+	"github.com/hightemp/github_repo_sync/internal/hooks"
+	"github.com/hightemp/github_repo_sync/internal/httpserver"
+	"github.com/hightemp/github_repo_sync/internal/metrics"
+	"github.com/hightemp/github_repo_sync/internal/remote"
 )
 
+// hookDispatchPoolSize bounds how many hook deliveries (exec/webhook/file)
+// can run concurrently, so a slow hook can't back up behind git workers.
+const hookDispatchPoolSize = 4
+
 type Config struct {
-	GithubToken   string `yaml:"github_token"`
-	GithubUser    string `yaml:"github_user"`
-	ReposDir      string `yaml:"repos_dir"`
-	PollInterval  string `yaml:"poll_interval"`
-	WorkerCount   int    `yaml:"worker_count"`
-	WorkQueueSize int    `yaml:"queue_size"`
+	GithubToken     string `yaml:"github_token"`
+	GithubUser      string `yaml:"github_user"`
+	ReposDir        string `yaml:"repos_dir"`
+	PollInterval    string `yaml:"poll_interval"`
+	WorkerCount     int    `yaml:"worker_count"`
+	WorkQueueSize   int    `yaml:"queue_size"`
+	ShutdownTimeout string `yaml:"shutdown_timeout"`
+
+	IncludeRepos    []string `yaml:"include_repos"`
+	ExcludeRepos    []string `yaml:"exclude_repos"`
+	IncludeOrgs     []string `yaml:"include_orgs"`
+	IncludeForks    bool     `yaml:"include_forks"`
+	IncludeArchived bool     `yaml:"include_archived"`
+	IncludeStarred  bool     `yaml:"include_starred"`
+	IncludeGists    bool     `yaml:"include_gists"`
+
+	MirrorMode   bool `yaml:"mirror_mode"`
+	CloneDepth   int  `yaml:"clone_depth"`
+	SingleBranch bool `yaml:"single_branch"`
+
+	RepoOverrides map[string]RepoOverride `yaml:"repo_overrides"`
+
+	Remotes []remote.Config `yaml:"remotes"`
+
+	HTTPAddr string `yaml:"http_addr"`
+
+	Hooks hooks.Config `yaml:"hooks"`
 }
 
 type RepoTask struct {
-	Repo     *github.Repository
-	RepoPath string
+	Repo       remote.RemoteRepo
+	RepoPath   string
+	RemoteName string
+	Auth       transport.AuthMethod
 }
 
 type Worker struct {
 	id          int
-	client      *github.Client
 	tasksChan   chan RepoTask
 	wg          *sync.WaitGroup
-	config      *Config
+	config      *configHolder
 	rateLimiter *time.Ticker
+	server      *httpserver.Server
+	hooks       *hooks.Dispatcher
 }
 
 func loadConfig(path string) (*Config, error) {
@@ -49,8 +79,9 @@ func loadConfig(path string) (*Config, error) {
 	}
 
 	config := Config{
-		WorkerCount:   5,
-		WorkQueueSize: 100,
+		WorkerCount:     5,
+		WorkQueueSize:   100,
+		ShutdownTimeout: "30s",
 	}
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, err
@@ -59,14 +90,15 @@ func loadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
-func newWorker(id int, client *github.Client, tasksChan chan RepoTask, wg *sync.WaitGroup, config *Config) *Worker {
+func newWorker(id int, tasksChan chan RepoTask, wg *sync.WaitGroup, config *configHolder, server *httpserver.Server, dispatcher *hooks.Dispatcher) *Worker {
 	return &Worker{
 		id:          id,
-		client:      client,
 		tasksChan:   tasksChan,
 		wg:          wg,
 		config:      config,
 		rateLimiter: time.NewTicker(time.Second / 10),
+		server:      server,
+		hooks:       dispatcher,
 	}
 }
 
@@ -79,9 +111,26 @@ func (w *Worker) start(ctx context.Context) {
 					return
 				}
 				<-w.rateLimiter.C
-				if err := w.processRepo(task); err != nil {
+				if m := graceful.GetManager(); m != nil {
+					m.AddRunningWorker()
+				}
+				err := w.processRepo(ctx, task)
+				if m := graceful.GetManager(); m != nil {
+					m.WorkerDone()
+				}
+				if err != nil {
 					log.Printf("Worker %d: Error processing repository %s: %v",
-						w.id, *task.Repo.Name, err)
+						w.id, task.Repo.Name, err)
+					metrics.RecordError(task.RemoteName, task.Repo.FullName)
+					if w.hooks != nil {
+						w.hooks.Dispatch(hooks.Event{
+							RepoName: task.Repo.FullName,
+							RepoPath: task.RepoPath,
+							Event:    "error",
+							Error:    err.Error(),
+							Time:     time.Now(),
+						})
+					}
 				}
 				w.wg.Done()
 			case <-ctx.Done():
@@ -91,23 +140,42 @@ func (w *Worker) start(ctx context.Context) {
 	}()
 }
 
-func (w *Worker) processRepo(task RepoTask) error {
-	auth := &http.BasicAuth{
-		Username: "git",
-		Password: w.config.GithubToken,
+func (w *Worker) processRepo(ctx context.Context, task RepoTask) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("aborting %s: %v", task.Repo.Name, ctx.Err())
 	}
 
+	config := w.config.Get()
+	auth := task.Auth
+	start := time.Now()
+	oldSHA := headSHA(task.RepoPath)
+	oldSize := dirSize(task.RepoPath)
+
+	settings := resolveRepoSettings(config, task.Repo.FullName)
+	if settings.Mirror {
+		event, err := w.processMirrorMode(ctx, task, auth, settings)
+		if err != nil {
+			return err
+		}
+		w.finishSync(task, start, oldSHA, oldSize, event)
+		return nil
+	}
+
+	event := "updated"
 	if _, err := os.Stat(task.RepoPath); os.IsNotExist(err) {
-		log.Printf("Worker %d: Cloning %s...", w.id, *task.Repo.Name)
-		_, err := git.PlainClone(task.RepoPath, false, &git.CloneOptions{
-			URL:  *task.Repo.CloneURL,
-			Auth: auth,
+		log.Printf("Worker %d: Cloning %s...", w.id, task.Repo.Name)
+		event = "cloned"
+		_, err := git.PlainCloneContext(ctx, task.RepoPath, false, &git.CloneOptions{
+			URL:          task.Repo.CloneURL,
+			Auth:         auth,
+			Depth:        settings.CloneDepth,
+			SingleBranch: settings.SingleBranch,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to clone repository: %v", err)
 		}
 	} else {
-		log.Printf("Worker %d: Pulling updates for %s...", w.id, *task.Repo.Name)
+		log.Printf("Worker %d: Pulling updates for %s...", w.id, task.Repo.Name)
 		r, err := git.PlainOpen(task.RepoPath)
 		if err != nil {
 			return fmt.Errorf("failed to open repository: %v", err)
@@ -118,69 +186,138 @@ func (w *Worker) processRepo(task RepoTask) error {
 			return fmt.Errorf("failed to get worktree: %v", err)
 		}
 
-		err = w.Pull(&git.PullOptions{
+		err = w.PullContext(ctx, &git.PullOptions{
 			Auth: auth,
 		})
 		if err == git.NoErrAlreadyUpToDate {
-			log.Printf("Repository %s is already up to date", *task.Repo.Name)
+			log.Printf("Repository %s is already up to date", task.Repo.Name)
+			event = "up_to_date"
 		} else if err != nil && err != git.ErrNonFastForwardUpdate {
 			return fmt.Errorf("failed to pull repository: %v", err)
 		}
 	}
+
+	w.finishSync(task, start, oldSHA, oldSize, event)
 	return nil
 }
 
-func syncRepos(ctx context.Context, config *Config) error {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: config.GithubToken},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+// finishSync records sync metrics, refreshes the /repos entry when the
+// HTTP server is enabled, and dispatches a hook event. The hook only
+// reports "updated" when oldSHA and the post-sync HEAD actually differ,
+// regardless of which clone/pull path produced them. Bytes fetched is
+// approximated as the on-disk size delta since before the sync, and is
+// only recorded for cloned/updated events so idle up_to_date polls don't
+// keep re-reporting the repo's full size.
+func (w *Worker) finishSync(task RepoTask, start time.Time, oldSHA string, oldSize int64, event string) {
+	duration := time.Since(start)
+	metrics.RecordSync(task.RemoteName, task.Repo.FullName, duration, event)
+	if event == "cloned" || event == "updated" {
+		metrics.RecordBytes(task.RemoteName, dirSize(task.RepoPath)-oldSize)
+	}
+
+	newSHA := headSHA(task.RepoPath)
+
+	if w.server != nil {
+		w.server.UpdateRepo(httpserver.RepoStatus{
+			Remote:   task.RemoteName,
+			Owner:    task.Repo.Owner,
+			Name:     task.Repo.Name,
+			Path:     task.RepoPath,
+			HeadSHA:  newSHA,
+			LastSync: time.Now(),
+		})
+	}
+
+	if w.hooks != nil {
+		hookEvent := event
+		if oldSHA != "" && oldSHA == newSHA {
+			hookEvent = "up_to_date"
+		}
+		w.hooks.Dispatch(hooks.Event{
+			RepoName: task.Repo.FullName,
+			RepoPath: task.RepoPath,
+			OldSHA:   oldSHA,
+			NewSHA:   newSHA,
+			Event:    hookEvent,
+			Time:     time.Now(),
+		})
+	}
+}
+
+// syncRepos runs a single sync pass across every configured remote.
+// shutdownCtx, when done, stops enumeration from enqueuing further work
+// (in-flight tasks are still drained); hammerCtx is handed to workers so a
+// long-running clone/pull aborts as soon as the shutdown_timeout elapses.
+func syncRepos(shutdownCtx, hammerCtx context.Context, holder *configHolder, server *httpserver.Server, dispatcher *hooks.Dispatcher) error {
+	config := holder.Get()
 
 	if err := os.MkdirAll(config.ReposDir, 0755); err != nil {
 		return fmt.Errorf("failed to create repos directory: %v", err)
 	}
 
+	remotes, err := buildRemotes(config)
+	if err != nil {
+		return fmt.Errorf("failed to configure remotes: %v", err)
+	}
+
 	tasksChan := make(chan RepoTask, config.WorkQueueSize)
 	defer close(tasksChan)
 	var wg sync.WaitGroup
 
 	for i := 0; i < config.WorkerCount; i++ {
-		worker := newWorker(i, client, tasksChan, &wg, config)
-		worker.start(ctx)
+		worker := newWorker(i, tasksChan, &wg, holder, server, dispatcher)
+		worker.start(hammerCtx)
 	}
 
-	opt := &github.RepositoryListOptions{
-		Visibility:  "all",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-
-	totalReposCnt := 0
+	found, included, excluded, skipped := 0, 0, 0, 0
+	for _, rb := range remotes {
+		if shutdownCtx.Err() != nil {
+			log.Println("Shutdown in progress, no longer enqueuing new repos")
+			break
+		}
 
-	for {
-		repos, resp, err := client.Repositories.List(ctx, "", opt)
+		repos, err := rb.ListRepos(shutdownCtx)
 		if err != nil {
-			return fmt.Errorf("failed to get repositories list: %v", err)
+			log.Printf("Failed to list repositories for remote %s: %v", rb.Name(), err)
+			continue
+		}
+		found += len(repos)
+		auth := rb.AuthMethod()
+
+		if limiter, ok := rb.Remote.(remote.RateLimiter); ok {
+			if remaining, err := limiter.RateLimitRemaining(shutdownCtx); err == nil {
+				metrics.SetRateLimitRemaining(rb.Name(), remaining)
+			}
 		}
 
-		totalReposCnt += len(repos)
+		if reporter, ok := rb.Remote.(remote.DuplicateReporter); ok {
+			skipped += reporter.SkippedDuplicates()
+		}
 
 		for _, repo := range repos {
-			repoPath := filepath.Join(config.ReposDir, *repo.Name)
+			if shutdownCtx.Err() != nil {
+				break
+			}
+			if !shouldIncludeRepo(&repo, rb.cfg.IncludeRepos, rb.cfg.ExcludeRepos, config.IncludeForks, config.IncludeArchived) {
+				excluded++
+				continue
+			}
+			included++
+
+			repoPath := filepath.Join(config.ReposDir, rb.Name(), repo.Owner, repo.Name)
 			wg.Add(1)
 			tasksChan <- RepoTask{
-				Repo:     repo,
-				RepoPath: repoPath,
+				Repo:       repo,
+				RepoPath:   repoPath,
+				RemoteName: rb.Name(),
+				Auth:       auth,
 			}
 		}
-
-		if resp.NextPage == 0 {
-			fmt.Printf("[!] Found %d repositories\n", totalReposCnt)
-			break
-		}
-		opt.Page = resp.NextPage
 	}
 
+	fmt.Printf("[!] Found %d, included %d, excluded %d, skipped %d duplicate(s) across %d remote(s)\n",
+		found, included, excluded, skipped, len(remotes))
+
 	wg.Wait()
 	return nil
 }
@@ -194,49 +331,85 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	interval, err := time.ParseDuration(config.PollInterval)
-	if err != nil {
+	if _, err := time.ParseDuration(config.PollInterval); err != nil {
 		log.Fatalf("Invalid poll interval: %v", err)
 	}
 
+	shutdownTimeout, err := time.ParseDuration(config.ShutdownTimeout)
+	if err != nil {
+		log.Fatalf("Invalid shutdown_timeout: %v", err)
+	}
+
+	holder := newConfigHolder(config)
+
 	log.Printf("Starting repository sync service...")
 	log.Printf("Repositories will be stored in: %s", config.ReposDir)
 	log.Printf("Using %d workers with queue size %d", config.WorkerCount, config.WorkQueueSize)
-	log.Printf("Polling interval: %s", interval)
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	log.Printf("Polling interval: %s", config.PollInterval)
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
-	errorChan := make(chan error, 1)
-	defer close(errorChan)
+	manager := graceful.Init(shutdownTimeout, func() error {
+		newConfig, err := loadConfig(*configPath)
+		if err != nil {
+			return err
+		}
+		holder.Set(newConfig)
+		log.Printf("Config reloaded: %d workers, queue size %d, poll interval %s",
+			newConfig.WorkerCount, newConfig.WorkQueueSize, newConfig.PollInterval)
+		return nil
+	})
+
+	dispatcher := hooks.NewDispatcher(config.Hooks)
+	dispatcher.Start(manager.HammerContext(), hookDispatchPoolSize)
+
+	var server *httpserver.Server
+	if config.HTTPAddr != "" {
+		server = httpserver.New(config.HTTPAddr)
+		httpErrc := make(chan error, 1)
+		server.Start(httpErrc)
+		log.Printf("HTTP server listening on %s", config.HTTPAddr)
+		go func() {
+			if err := <-httpErrc; err != nil {
+				log.Printf("HTTP server error: %v", err)
+			}
+		}()
+	}
 
+	firstPass := true
 	go func() {
 		for {
-			select {
-			case <-ctx.Done():
+			if manager.ShutdownContext().Err() != nil {
 				return
-			default:
-				if err := syncRepos(ctx, config); err != nil {
-					errorChan <- err
-					return
-				}
+			}
+
+			if err := syncRepos(manager.ShutdownContext(), manager.HammerContext(), holder, server, dispatcher); err != nil {
+				log.Printf("Error during sync: %v", err)
+			} else {
 				log.Println("Syncing repos finished")
-				time.Sleep(interval)
+				if firstPass && server != nil {
+					server.SetReady()
+					firstPass = false
+				}
+			}
+
+			interval, err := time.ParseDuration(holder.Get().PollInterval)
+			if err != nil {
+				log.Printf("Invalid poll interval, keeping previous schedule: %v", err)
+				interval = time.Minute
+			}
+
+			select {
+			case <-time.After(interval):
+			case <-manager.ShutdownContext().Done():
+				return
 			}
 		}
 	}()
 
-	select {
-	case <-sigChan:
-		log.Println("Received shutdown signal. Finishing current tasks...")
-		cancel()
-	case err := <-errorChan:
-		log.Printf("Error during sync: %v", err)
-		cancel()
+	<-manager.Done()
+	if server != nil {
+		if err := server.Shutdown(); err != nil {
+			log.Printf("Error shutting down HTTP server: %v", err)
+		}
 	}
-
 	log.Println("Service stopped")
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// RepoOverride lets a specific repo (keyed by "owner/name" in
+// Config.RepoOverrides) deviate from the global mirror/shallow-clone
+// settings. Pointer fields distinguish "not set" from the zero value.
+type RepoOverride struct {
+	MirrorMode   *bool `yaml:"mirror_mode"`
+	CloneDepth   *int  `yaml:"clone_depth"`
+	SingleBranch *bool `yaml:"single_branch"`
+}
+
+// repoSettings is the effective clone configuration for one repo, after
+// applying any per-repo override on top of the global defaults.
+type repoSettings struct {
+	Mirror       bool
+	CloneDepth   int
+	SingleBranch bool
+}
+
+func resolveRepoSettings(config *Config, fullName string) repoSettings {
+	settings := repoSettings{
+		Mirror:       config.MirrorMode,
+		CloneDepth:   config.CloneDepth,
+		SingleBranch: config.SingleBranch,
+	}
+
+	override, ok := config.RepoOverrides[fullName]
+	if !ok {
+		return settings
+	}
+	if override.MirrorMode != nil {
+		settings.Mirror = *override.MirrorMode
+	}
+	if override.CloneDepth != nil {
+		settings.CloneDepth = *override.CloneDepth
+	}
+	if override.SingleBranch != nil {
+		settings.SingleBranch = *override.SingleBranch
+	}
+	return settings
+}
+
+// processMirrorMode clones task.Repo as a bare mirror on first sync, and on
+// subsequent syncs fetches every ref (pruning ones removed upstream) rather
+// than maintaining a working tree. This avoids the non-fast-forward
+// conflicts a checked-out worktree runs into when history is rewritten
+// upstream.
+func (w *Worker) processMirrorMode(ctx context.Context, task RepoTask, auth transport.AuthMethod, settings repoSettings) (string, error) {
+	repo, err := git.PlainOpen(task.RepoPath)
+	if err != nil {
+		log.Printf("Worker %d: Mirror-cloning %s...", w.id, task.Repo.Name)
+		_, err := git.PlainCloneContext(ctx, task.RepoPath, true, &git.CloneOptions{
+			URL:          task.Repo.CloneURL,
+			Auth:         auth,
+			Mirror:       true,
+			Depth:        settings.CloneDepth,
+			SingleBranch: settings.SingleBranch,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to mirror-clone repository: %v", err)
+		}
+		return "cloned", nil
+	}
+
+	log.Printf("Worker %d: Fetching updates for mirror %s...", w.id, task.Repo.Name)
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return "", fmt.Errorf("failed to get origin remote: %v", err)
+	}
+
+	err = remote.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []gitconfig.RefSpec{"+refs/*:refs/*"},
+		Auth:     auth,
+		Depth:    settings.CloneDepth,
+		Prune:    true,
+		Tags:     git.AllTags,
+	})
+	if err == git.NoErrAlreadyUpToDate {
+		log.Printf("Mirror %s is already up to date", task.Repo.Name)
+		return "up_to_date", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to fetch mirror: %v", err)
+	}
+	return "updated", nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import "github.com/hightemp/github_repo_sync/internal/remote"
+
+// remoteBinding pairs a constructed Remote with the config it was built
+// from, so syncRepos can apply that remote's own include/exclude filters.
+type remoteBinding struct {
+	remote.Remote
+	cfg remote.Config
+}
+
+// buildRemotes returns the configured remotes to sync. When config.Remotes
+// is empty it falls back to a single GitHub remote built from the
+// top-level legacy fields, preserving pre-multi-remote behavior.
+func buildRemotes(config *Config) ([]remoteBinding, error) {
+	remoteConfigs := config.Remotes
+	if len(remoteConfigs) == 0 {
+		remoteConfigs = []remote.Config{
+			{
+				Type:           "github",
+				Name:           "github",
+				Token:          config.GithubToken,
+				UserOrOrg:      config.GithubUser,
+				IncludeRepos:   config.IncludeRepos,
+				ExcludeRepos:   config.ExcludeRepos,
+				IncludeOrgs:    config.IncludeOrgs,
+				IncludeStarred: config.IncludeStarred,
+				IncludeGists:   config.IncludeGists,
+			},
+		}
+	}
+
+	bindings := make([]remoteBinding, 0, len(remoteConfigs))
+	for _, rc := range remoteConfigs {
+		r, err := remote.New(rc)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, remoteBinding{Remote: r, cfg: rc})
+	}
+	return bindings, nil
+}
@@ -0,0 +1,18 @@
+package main
+
+import "github.com/go-git/go-git/v5"
+
+// headSHA returns the current HEAD commit hash for the repo at path, or
+// "" if it doesn't exist yet or HEAD can't be resolved (e.g. an empty
+// repository).
+func headSHA(path string) string {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return ""
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
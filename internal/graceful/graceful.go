@@ -0,0 +1,165 @@
+// Package graceful provides a shutdown manager that lets in-flight work
+// finish cleanly on SIGINT/SIGTERM and reloads configuration on SIGHUP,
+// instead of every caller wiring up its own signal handling and cancel().
+package graceful
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Manager coordinates graceful shutdown for the service. A single instance
+// is created at startup via Init and shared by every long-running
+// component (sync loop, workers, HTTP server).
+type Manager struct {
+	mu sync.Mutex
+
+	shutdownCtx    context.Context
+	shutdownCancel context.CancelFunc
+	hammerCtx      context.Context
+	hammerCancel   context.CancelFunc
+
+	shutdownTimeout time.Duration
+	reload          func() error
+
+	runningWorkers sync.WaitGroup
+	terminate      chan struct{}
+}
+
+var defaultManager *Manager
+
+// Init creates the process-wide Manager, starts its signal handling loop
+// and returns it. reload, if non-nil, is invoked when SIGHUP is received
+// and should re-read configuration and apply it to running components.
+func Init(shutdownTimeout time.Duration, reload func() error) *Manager {
+	hammerCtx, hammerCancel := context.WithCancel(context.Background())
+	shutdownCtx, shutdownCancel := context.WithCancel(hammerCtx)
+
+	m := &Manager{
+		shutdownCtx:     shutdownCtx,
+		shutdownCancel:  shutdownCancel,
+		hammerCtx:       hammerCtx,
+		hammerCancel:    hammerCancel,
+		shutdownTimeout: shutdownTimeout,
+		reload:          reload,
+		terminate:       make(chan struct{}),
+	}
+
+	defaultManager = m
+	go m.handleSignals()
+	return m
+}
+
+// GetManager returns the process-wide Manager created by Init, or nil if
+// Init has not been called yet.
+func GetManager() *Manager {
+	return defaultManager
+}
+
+func (m *Manager) handleSignals() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	for {
+		select {
+		case sig := <-sigChan:
+			switch sig {
+			case syscall.SIGHUP:
+				m.doReload()
+			default:
+				if m.shutdownCtx.Err() != nil {
+					log.Println("graceful: second shutdown signal received, hammering now")
+					m.doHammer()
+					return
+				}
+				log.Println("graceful: shutdown signal received, draining in-flight work")
+				m.doShutdown()
+			}
+		case <-m.hammerCtx.Done():
+			return
+		}
+	}
+}
+
+func (m *Manager) doShutdown() {
+	m.shutdownCancel()
+
+	go func() {
+		timer := time.NewTimer(m.shutdownTimeout)
+		defer timer.Stop()
+
+		done := make(chan struct{})
+		go func() {
+			m.runningWorkers.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			log.Println("graceful: all workers finished, shutting down")
+		case <-timer.C:
+			log.Printf("graceful: shutdown_timeout (%s) elapsed, hammering remaining workers", m.shutdownTimeout)
+		}
+		m.doHammer()
+	}()
+}
+
+func (m *Manager) doHammer() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case <-m.hammerCtx.Done():
+	default:
+		m.hammerCancel()
+		close(m.terminate)
+	}
+}
+
+func (m *Manager) doReload() {
+	if m.reload == nil {
+		return
+	}
+	log.Println("graceful: SIGHUP received, reloading configuration")
+	if err := m.reload(); err != nil {
+		log.Printf("graceful: failed to reload configuration: %v", err)
+	}
+}
+
+// ShutdownContext is canceled as soon as a shutdown signal is received.
+// Long-running loops should stop enqueuing new work when this context is
+// done, while letting work already in flight finish.
+func (m *Manager) ShutdownContext() context.Context {
+	return m.shutdownCtx
+}
+
+// HammerContext is canceled once every running worker has finished after
+// shutdown began or the shutdown_timeout has elapsed, whichever comes
+// first, or immediately on a second shutdown signal. In-flight
+// work should abort as soon as this context is done.
+func (m *Manager) HammerContext() context.Context {
+	return m.hammerCtx
+}
+
+// Done is closed once the process should exit, i.e. after the hammer
+// context has been canceled.
+func (m *Manager) Done() <-chan struct{} {
+	return m.terminate
+}
+
+// AddRunningWorker registers a worker that should be allowed to finish
+// before the hammer context fires. Callers must call WorkerDone when the
+// worker exits.
+func (m *Manager) AddRunningWorker() {
+	m.runningWorkers.Add(1)
+}
+
+// WorkerDone marks a worker registered via AddRunningWorker as finished.
+func (m *Manager) WorkerDone() {
+	m.runningWorkers.Done()
+}
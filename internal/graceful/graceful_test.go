@@ -0,0 +1,84 @@
+package graceful
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestManager builds a Manager the same way Init does, but without
+// starting the signal-handling goroutine, so tests can drive state
+// transitions directly via doShutdown/doHammer.
+func newTestManager(shutdownTimeout time.Duration) *Manager {
+	hammerCtx, hammerCancel := context.WithCancel(context.Background())
+	shutdownCtx, shutdownCancel := context.WithCancel(hammerCtx)
+
+	return &Manager{
+		shutdownCtx:     shutdownCtx,
+		shutdownCancel:  shutdownCancel,
+		hammerCtx:       hammerCtx,
+		hammerCancel:    hammerCancel,
+		shutdownTimeout: shutdownTimeout,
+		terminate:       make(chan struct{}),
+	}
+}
+
+func TestDoShutdownWaitsForRunningWorkers(t *testing.T) {
+	m := newTestManager(time.Second)
+
+	m.AddRunningWorker()
+	m.doShutdown()
+
+	if m.ShutdownContext().Err() == nil {
+		t.Fatal("ShutdownContext should be canceled as soon as shutdown starts")
+	}
+
+	select {
+	case <-m.Done():
+		t.Fatal("Done closed before the running worker finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.WorkerDone()
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done was not closed after the running worker finished")
+	}
+
+	if m.HammerContext().Err() == nil {
+		t.Fatal("HammerContext should be canceled once Done closes")
+	}
+}
+
+func TestDoShutdownHammersAfterTimeout(t *testing.T) {
+	m := newTestManager(20 * time.Millisecond)
+
+	m.AddRunningWorker() // never marked done
+	m.doShutdown()
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done was not closed after shutdown_timeout elapsed")
+	}
+
+	if m.HammerContext().Err() == nil {
+		t.Fatal("HammerContext should be canceled once the timeout hammers remaining workers")
+	}
+}
+
+func TestDoHammerIsIdempotent(t *testing.T) {
+	m := newTestManager(time.Second)
+
+	m.doHammer()
+	select {
+	case <-m.Done():
+	default:
+		t.Fatal("Done should be closed after the first doHammer")
+	}
+
+	// A second call must not panic (e.g. close of closed channel).
+	m.doHammer()
+}
@@ -0,0 +1,27 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// runExec runs command with the sync event exposed as environment
+// variables, matching the shape of a VCS post-receive hook.
+func runExec(command string, event Event) error {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"REPO_NAME="+event.RepoName,
+		"REPO_PATH="+event.RepoPath,
+		"OLD_SHA="+event.OldSHA,
+		"NEW_SHA="+event.NewSHA,
+		"EVENT="+event.Event,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook command failed: %v", err)
+	}
+	return nil
+}
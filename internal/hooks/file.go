@@ -0,0 +1,25 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// appendFile appends event as a single JSON line to path, creating it if
+// necessary, for downstream consumers that tail the file.
+func appendFile(path string, event Event) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	_, err = f.Write(line)
+	return err
+}
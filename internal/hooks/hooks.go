@@ -0,0 +1,117 @@
+// Package hooks lets users react to sync events via a local command, a
+// signed webhook, or a newline-delimited JSON event log, without blocking
+// the git workers that triggered them.
+package hooks
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Event describes one repo sync outcome.
+type Event struct {
+	RepoName string    `json:"repo_name"`
+	RepoPath string    `json:"repo_path"`
+	OldSHA   string    `json:"old_sha,omitempty"`
+	NewSHA   string    `json:"new_sha,omitempty"`
+	Event    string    `json:"event"` // cloned, updated, up_to_date, error
+	Error    string    `json:"error,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// WebhookConfig configures the signed HTTP POST hook.
+type WebhookConfig struct {
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+}
+
+// Config is the `hooks:` section of the service config.
+type Config struct {
+	Exec    string         `yaml:"exec"`
+	Webhook *WebhookConfig `yaml:"webhook"`
+	File    string         `yaml:"file"`
+}
+
+func (c Config) enabled() bool {
+	return c.Exec != "" || c.Webhook != nil || c.File != ""
+}
+
+// Dispatcher fans event dispatch out to a small bounded pool so a slow
+// exec hook or webhook endpoint can't stall the git workers that produce
+// events.
+type Dispatcher struct {
+	cfg   Config
+	queue chan Event
+}
+
+// NewDispatcher builds a Dispatcher for cfg. If cfg has no hooks
+// configured, Dispatch is a no-op and no workers are started.
+func NewDispatcher(cfg Config) *Dispatcher {
+	return &Dispatcher{
+		cfg:   cfg,
+		queue: make(chan Event, 256),
+	}
+}
+
+// Start launches the dispatch worker pool. It is a no-op if no hooks are
+// configured.
+func (d *Dispatcher) Start(ctx context.Context, poolSize int) {
+	if !d.cfg.enabled() {
+		return
+	}
+
+	if poolSize < 1 {
+		poolSize = 1
+	}
+
+	for i := 0; i < poolSize; i++ {
+		go d.worker(ctx)
+	}
+}
+
+func (d *Dispatcher) worker(ctx context.Context) {
+	for {
+		select {
+		case event, ok := <-d.queue:
+			if !ok {
+				return
+			}
+			d.dispatch(event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(event Event) {
+	if d.cfg.Exec != "" {
+		if err := runExec(d.cfg.Exec, event); err != nil {
+			log.Printf("hooks: exec hook failed for %s: %v", event.RepoName, err)
+		}
+	}
+	if d.cfg.Webhook != nil {
+		if err := postWebhook(*d.cfg.Webhook, event); err != nil {
+			log.Printf("hooks: webhook failed for %s: %v", event.RepoName, err)
+		}
+	}
+	if d.cfg.File != "" {
+		if err := appendFile(d.cfg.File, event); err != nil {
+			log.Printf("hooks: file hook failed for %s: %v", event.RepoName, err)
+		}
+	}
+}
+
+// Dispatch enqueues event for the hook pool. It drops the event (logging a
+// warning) rather than blocking the calling git worker if the queue is
+// full.
+func (d *Dispatcher) Dispatch(event Event) {
+	if !d.cfg.enabled() {
+		return
+	}
+	select {
+	case d.queue <- event:
+	default:
+		log.Printf("hooks: queue full, dropping %s event for %s", event.Event, event.RepoName)
+	}
+}
@@ -0,0 +1,78 @@
+package httpserver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// handleArchive streams a tar.gz of a mirrored repo's working tree (or
+// bare directory, in mirror_mode) so downstream build systems can pull a
+// snapshot without running git themselves.
+//
+// Path shape: /archive/{remote}/{owner}/{name}.tar.gz
+func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/archive/")
+	key = strings.TrimSuffix(key, ".tar.gz")
+
+	s.mu.RLock()
+	status, ok := s.repos[key]
+	s.mu.RUnlock()
+	if !ok {
+		http.Error(w, "repository not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", status.Name+".tar.gz"))
+
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	err := filepath.Walk(status.Path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(status.Path, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to archive repository: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,119 @@
+// Package httpserver exposes an optional HTTP endpoint alongside the sync
+// loop: Prometheus metrics, liveness/readiness probes, a JSON listing of
+// mirrored repos, and tarball downloads of their working trees for
+// downstream build systems to consume.
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// RepoStatus is a point-in-time snapshot of one synced repository, served
+// from GET /repos.
+type RepoStatus struct {
+	Remote   string    `json:"remote"`
+	Owner    string    `json:"owner"`
+	Name     string    `json:"name"`
+	Path     string    `json:"path"`
+	HeadSHA  string    `json:"head_sha,omitempty"`
+	LastSync time.Time `json:"last_sync"`
+}
+
+// Server is the optional HTTP server started when http_addr is configured.
+type Server struct {
+	mu    sync.RWMutex
+	repos map[string]RepoStatus
+
+	ready int32
+
+	httpServer *http.Server
+}
+
+// New builds a Server listening on addr. Call Start to actually serve.
+func New(addr string) *Server {
+	s := &Server{
+		repos: make(map[string]RepoStatus),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/repos", s.handleRepos)
+	mux.HandleFunc("/archive/", s.handleArchive)
+
+	s.httpServer = &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+	return s
+}
+
+// Start begins serving in the background. Any error from ListenAndServe,
+// including a failure to listen, is sent to errc; http.ErrServerClosed
+// (returned after Shutdown) is not.
+func (s *Server) Start(errc chan<- error) {
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errc <- err
+		}
+	}()
+}
+
+// Shutdown stops the HTTP server immediately, closing its listener and any
+// active connections without waiting for in-flight requests to finish.
+func (s *Server) Shutdown() error {
+	return s.httpServer.Close()
+}
+
+// SetReady marks the service as ready; /readyz returns 200 from then on.
+// It should be called once the first sync pass completes.
+func (s *Server) SetReady() {
+	atomic.StoreInt32(&s.ready, 1)
+}
+
+// UpdateRepo records the latest sync status for a repo, keyed by
+// remote/owner/name.
+func (s *Server) UpdateRepo(status RepoStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.repos[repoKey(status.Remote, status.Owner, status.Name)] = status
+}
+
+func repoKey(remote, owner, name string) string {
+	return remote + "/" + owner + "/" + name
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&s.ready) == 0 {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ready"))
+}
+
+func (s *Server) handleRepos(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	list := make([]RepoStatus, 0, len(s.repos))
+	for _, status := range s.repos {
+		list = append(list, status)
+	}
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
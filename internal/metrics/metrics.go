@@ -0,0 +1,65 @@
+// Package metrics holds the Prometheus collectors exported on /metrics.
+// Collectors are registered on the default registry at package init so
+// promhttp.Handler() picks them up without any wiring from callers.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	syncDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "github_repo_sync_repo_duration_seconds",
+		Help:    "Time taken to clone or pull a single repository.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"remote", "repo"})
+
+	bytesFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "github_repo_sync_bytes_fetched_total",
+		Help: "Approximate bytes fetched by remote, as the on-disk size delta of a repository across a clone or update (not counted for up_to_date syncs).",
+	}, []string{"remote"})
+
+	operationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "github_repo_sync_operations_total",
+		Help: "Count of sync operations by remote and outcome (cloned, updated, up_to_date); errors are counted in github_repo_sync_repo_errors_total.",
+	}, []string{"remote", "event"})
+
+	errorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "github_repo_sync_repo_errors_total",
+		Help: "Count of sync errors by repository.",
+	}, []string{"remote", "repo"})
+
+	rateLimitRemaining = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_repo_sync_rate_limit_remaining",
+		Help: "Remaining API rate limit as last reported by a remote, if it exposes one.",
+	}, []string{"remote"})
+)
+
+// RecordSync records how long a clone/pull took and bumps the
+// operation/event counter for remote.
+func RecordSync(remote, repo string, duration time.Duration, event string) {
+	syncDuration.WithLabelValues(remote, repo).Observe(duration.Seconds())
+	operationsTotal.WithLabelValues(remote, event).Inc()
+}
+
+// RecordBytes adds n bytes to the fetched-bytes counter for remote.
+func RecordBytes(remote string, n int64) {
+	if n <= 0 {
+		return
+	}
+	bytesFetched.WithLabelValues(remote).Add(float64(n))
+}
+
+// RecordError increments the per-repo error counter.
+func RecordError(remote, repo string) {
+	errorsTotal.WithLabelValues(remote, repo).Inc()
+}
+
+// SetRateLimitRemaining records the last-known remaining API quota for a
+// remote that supports reporting one (currently only GitHub).
+func SetRateLimitRemaining(remote string, remaining int) {
+	rateLimitRemaining.WithLabelValues(remote).Set(float64(remaining))
+}
@@ -0,0 +1,104 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+type bitbucketRemote struct {
+	name string
+	cfg  Config
+}
+
+func newBitbucketRemote(name string, cfg Config) Remote {
+	return &bitbucketRemote{name: name, cfg: cfg}
+}
+
+func (r *bitbucketRemote) Name() string {
+	return r.name
+}
+
+func (r *bitbucketRemote) AuthMethod() transport.AuthMethod {
+	return basicAuth("x-token-auth", r.cfg.Token)
+}
+
+type bitbucketRepo struct {
+	Slug    string `json:"slug"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Archived bool `json:"archived"`
+	Origin   *struct {
+		Slug string `json:"slug"`
+	} `json:"origin"`
+	Links struct {
+		Clone []struct {
+			Href string `json:"href"`
+			Name string `json:"name"`
+		} `json:"clone"`
+	} `json:"links"`
+}
+
+type bitbucketPage struct {
+	Values        []bitbucketRepo `json:"values"`
+	IsLastPage    bool            `json:"isLastPage"`
+	NextPageStart int             `json:"nextPageStart"`
+}
+
+// ListRepos walks Bitbucket Server's project-scoped repos endpoint when
+// UserOrOrg (a project key) is set, or the instance-wide repos endpoint
+// otherwise.
+func (r *bitbucketRemote) ListRepos(ctx context.Context) ([]RemoteRepo, error) {
+	base := strings.TrimRight(r.cfg.BaseURL, "/")
+	listPath := fmt.Sprintf("%s/rest/api/1.0/repos", base)
+	if r.cfg.UserOrOrg != "" {
+		listPath = fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos", base, r.cfg.UserOrOrg)
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + r.cfg.Token}
+
+	var repos []RemoteRepo
+	start := 0
+	for {
+		var page bitbucketPage
+		url := fmt.Sprintf("%s?start=%d&limit=100", listPath, start)
+		if err := getJSON(ctx, url, headers, &page); err != nil {
+			return nil, err
+		}
+
+		for _, repo := range page.Values {
+			repos = append(repos, RemoteRepo{
+				Owner:      repo.Project.Key,
+				Name:       repo.Slug,
+				FullName:   fmt.Sprintf("%s/%s", repo.Project.Key, repo.Slug),
+				CloneURL:   cloneHref(repo.Links.Clone),
+				IsFork:     repo.Origin != nil,
+				IsArchived: repo.Archived,
+			})
+		}
+
+		if page.IsLastPage {
+			break
+		}
+		start = page.NextPageStart
+	}
+	return repos, nil
+}
+
+func cloneHref(links []struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}) string {
+	for _, link := range links {
+		if link.Name == "http" || link.Name == "https" {
+			return link.Href
+		}
+	}
+	if len(links) > 0 {
+		return links[0].Href
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package remote
+
+// repoSet deduplicates repos discovered across a single remote's own
+// enumeration sources (e.g. GitHub's user/org/starred/gist listings).
+type repoSet struct {
+	repos   map[string]RemoteRepo
+	skipped int
+}
+
+func newRepoSet() *repoSet {
+	return &repoSet{repos: make(map[string]RemoteRepo)}
+}
+
+func (s *repoSet) add(repo RemoteRepo) {
+	if _, ok := s.repos[repo.FullName]; ok {
+		s.skipped++
+		return
+	}
+	s.repos[repo.FullName] = repo
+}
+
+func (s *repoSet) list() []RemoteRepo {
+	out := make([]RemoteRepo, 0, len(s.repos))
+	for _, repo := range s.repos {
+		out = append(out, repo)
+	}
+	return out
+}
+
+// Skipped reports how many adds were duplicates of a repo already in the
+// set, i.e. repos seen across more than one enumeration source.
+func (s *repoSet) Skipped() int {
+	return s.skipped
+}
@@ -0,0 +1,90 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+type gerritRemote struct {
+	name string
+	cfg  Config
+}
+
+func newGerritRemote(name string, cfg Config) Remote {
+	return &gerritRemote{name: name, cfg: cfg}
+}
+
+func (r *gerritRemote) Name() string {
+	return r.name
+}
+
+func (r *gerritRemote) AuthMethod() transport.AuthMethod {
+	return basicAuth(r.cfg.UserOrOrg, r.cfg.Token)
+}
+
+type gerritProjectInfo struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// gerritMagicPrefix guards Gerrit's JSON responses against XSSI and must be
+// stripped before the body can be unmarshaled.
+var gerritMagicPrefix = []byte(")]}'")
+
+// ListRepos fetches Gerrit's project list (the "projects" API, not to be
+// confused with a VCS repo list elsewhere) and maps every project to a
+// RemoteRepo; projects whose state is not ACTIVE are marked IsArchived.
+func (r *gerritRemote) ListRepos(ctx context.Context) ([]RemoteRepo, error) {
+	base := strings.TrimRight(r.cfg.BaseURL, "/")
+	url := fmt.Sprintf("%s/a/projects/?d", base)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(r.cfg.UserOrOrg, r.cfg.Token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s: %s", resp.Status, url, body)
+	}
+
+	body = bytes.TrimPrefix(body, gerritMagicPrefix)
+	body = bytes.TrimLeft(body, "\n")
+
+	var projects map[string]gerritProjectInfo
+	if err := json.Unmarshal(body, &projects); err != nil {
+		return nil, err
+	}
+
+	var repos []RemoteRepo
+	for name, project := range projects {
+		if project.Name != "" {
+			name = project.Name
+		}
+		repos = append(repos, RemoteRepo{
+			Owner:      r.name,
+			Name:       name,
+			FullName:   name,
+			CloneURL:   fmt.Sprintf("%s/a/%s", base, name),
+			IsArchived: project.State != "" && project.State != "ACTIVE",
+		})
+	}
+	return repos, nil
+}
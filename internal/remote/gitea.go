@@ -0,0 +1,70 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+type giteaRemote struct {
+	name string
+	cfg  Config
+}
+
+func newGiteaRemote(name string, cfg Config) Remote {
+	return &giteaRemote{name: name, cfg: cfg}
+}
+
+func (r *giteaRemote) Name() string {
+	return r.name
+}
+
+func (r *giteaRemote) AuthMethod() transport.AuthMethod {
+	return basicAuth(r.cfg.UserOrOrg, r.cfg.Token)
+}
+
+type giteaRepo struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+	Fork     bool   `json:"fork"`
+	Archived bool   `json:"archived"`
+	Owner    struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+func (r *giteaRemote) ListRepos(ctx context.Context) ([]RemoteRepo, error) {
+	base := strings.TrimRight(r.cfg.BaseURL, "/")
+	listPath := fmt.Sprintf("%s/api/v1/user/repos", base)
+	if r.cfg.UserOrOrg != "" {
+		listPath = fmt.Sprintf("%s/api/v1/users/%s/repos", base, r.cfg.UserOrOrg)
+	}
+
+	headers := map[string]string{"Authorization": "token " + r.cfg.Token}
+
+	var repos []RemoteRepo
+	for page := 1; ; page++ {
+		var batch []giteaRepo
+		url := fmt.Sprintf("%s?limit=50&page=%d", listPath, page)
+		if err := getJSON(ctx, url, headers, &batch); err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, repo := range batch {
+			repos = append(repos, RemoteRepo{
+				Owner:      repo.Owner.Login,
+				Name:       repo.Name,
+				FullName:   repo.FullName,
+				CloneURL:   repo.CloneURL,
+				IsFork:     repo.Fork,
+				IsArchived: repo.Archived,
+			})
+		}
+	}
+	return repos, nil
+}
@@ -0,0 +1,199 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/google/go-github/v45/github"
+	"golang.org/x/oauth2"
+)
+
+type githubRemote struct {
+	name   string
+	cfg    Config
+	client *github.Client
+
+	skipped int
+}
+
+func newGitHubRemote(name string, cfg Config) Remote {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.Token})
+	tc := oauth2.NewClient(context.Background(), ts)
+	return &githubRemote{
+		name:   name,
+		cfg:    cfg,
+		client: github.NewClient(tc),
+	}
+}
+
+func (r *githubRemote) Name() string {
+	return r.name
+}
+
+func (r *githubRemote) AuthMethod() transport.AuthMethod {
+	return basicAuth("git", r.cfg.Token)
+}
+
+// RateLimitRemaining reports the remaining core API quota. Callers type
+// assert for this optional interface since not every Remote backs onto an
+// API with rate limits worth surfacing.
+func (r *githubRemote) RateLimitRemaining(ctx context.Context) (int, error) {
+	limits, _, err := r.client.RateLimits(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return limits.GetCore().Remaining, nil
+}
+
+// ListRepos enumerates the repos of UserOrOrg (the authenticated user's
+// when it is empty), any orgs configured
+// via IncludeOrgs, and (when enabled) starred repos and gists, merging them
+// into one deduplicated list.
+func (r *githubRemote) ListRepos(ctx context.Context) ([]RemoteRepo, error) {
+	set := newRepoSet()
+
+	if err := r.listUserRepos(ctx, set); err != nil {
+		return nil, fmt.Errorf("failed to list user repositories: %v", err)
+	}
+
+	for _, org := range r.cfg.IncludeOrgs {
+		if err := r.listOrgRepos(ctx, org, set); err != nil {
+			return nil, fmt.Errorf("failed to list repositories for org %s: %v", org, err)
+		}
+	}
+
+	if r.cfg.IncludeStarred {
+		if err := r.listStarredRepos(ctx, set); err != nil {
+			return nil, fmt.Errorf("failed to list starred repositories: %v", err)
+		}
+	}
+
+	if r.cfg.IncludeGists {
+		if err := r.listGists(ctx, set); err != nil {
+			return nil, fmt.Errorf("failed to list gists: %v", err)
+		}
+	}
+
+	r.skipped = set.Skipped()
+	return set.list(), nil
+}
+
+// SkippedDuplicates reports how many repos were seen across more than one
+// enumeration source (user/org/starred/gists) in the last ListRepos call.
+func (r *githubRemote) SkippedDuplicates() int {
+	return r.skipped
+}
+
+func (r *githubRemote) listUserRepos(ctx context.Context, set *repoSet) error {
+	opt := &github.RepositoryListOptions{
+		Visibility:  "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		repos, resp, err := r.client.Repositories.List(ctx, r.cfg.UserOrOrg, opt)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			set.add(repoFromGithub(repo))
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+func (r *githubRemote) listOrgRepos(ctx context.Context, org string, set *repoSet) error {
+	opt := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		repos, resp, err := r.client.Repositories.ListByOrg(ctx, org, opt)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			set.add(repoFromGithub(repo))
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+func (r *githubRemote) listStarredRepos(ctx context.Context, set *repoSet) error {
+	opt := &github.ActivityListStarredOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		starred, resp, err := r.client.Activity.ListStarred(ctx, r.cfg.UserOrOrg, opt)
+		if err != nil {
+			return err
+		}
+		for _, s := range starred {
+			if s.Repository == nil {
+				continue
+			}
+			set.add(repoFromGithub(s.Repository))
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+func (r *githubRemote) listGists(ctx context.Context, set *repoSet) error {
+	opt := &github.GistListOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		gists, resp, err := r.client.Gists.List(ctx, r.cfg.UserOrOrg, opt)
+		if err != nil {
+			return err
+		}
+		for _, gist := range gists {
+			set.add(repoFromGist(gist))
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+func repoFromGithub(repo *github.Repository) RemoteRepo {
+	owner := ""
+	if repo.Owner != nil {
+		owner = repo.Owner.GetLogin()
+	}
+	return RemoteRepo{
+		Owner:      owner,
+		Name:       repo.GetName(),
+		FullName:   repo.GetFullName(),
+		CloneURL:   repo.GetCloneURL(),
+		IsFork:     repo.GetFork(),
+		IsArchived: repo.GetArchived(),
+	}
+}
+
+func repoFromGist(gist *github.Gist) RemoteRepo {
+	owner := ""
+	if gist.Owner != nil {
+		owner = gist.Owner.GetLogin()
+	}
+	name := gist.GetID()
+	return RemoteRepo{
+		Owner:    owner,
+		Name:     name,
+		FullName: fmt.Sprintf("%s/%s", owner, name),
+		CloneURL: gist.GetGitPullURL(),
+	}
+}
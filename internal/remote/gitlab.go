@@ -0,0 +1,77 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+type gitlabRemote struct {
+	name string
+	cfg  Config
+}
+
+func newGitLabRemote(name string, cfg Config) Remote {
+	return &gitlabRemote{name: name, cfg: cfg}
+}
+
+func (r *gitlabRemote) Name() string {
+	return r.name
+}
+
+func (r *gitlabRemote) AuthMethod() transport.AuthMethod {
+	return basicAuth("oauth2", r.cfg.Token)
+}
+
+type gitlabProject struct {
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	Archived          bool   `json:"archived"`
+	ForkedFromProject *struct {
+		ID int `json:"id"`
+	} `json:"forked_from_project"`
+	Namespace struct {
+		Path string `json:"path"`
+	} `json:"namespace"`
+}
+
+func (r *gitlabRemote) ListRepos(ctx context.Context) ([]RemoteRepo, error) {
+	base := strings.TrimRight(r.cfg.BaseURL, "/")
+	listPath := fmt.Sprintf("%s/api/v4/projects?membership=true", base)
+	if r.cfg.UserOrOrg != "" {
+		// Subgroups are addressed as "top/sub"; GitLab requires the slash
+		// percent-encoded rather than treated as a path separator.
+		listPath = fmt.Sprintf("%s/api/v4/groups/%s/projects?per_page=50", base, url.PathEscape(r.cfg.UserOrOrg))
+	} else {
+		listPath += "&per_page=50"
+	}
+
+	headers := map[string]string{"PRIVATE-TOKEN": r.cfg.Token}
+
+	var repos []RemoteRepo
+	for page := 1; ; page++ {
+		var batch []gitlabProject
+		url := fmt.Sprintf("%s&page=%d", listPath, page)
+		if err := getJSON(ctx, url, headers, &batch); err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, project := range batch {
+			repos = append(repos, RemoteRepo{
+				Owner:      project.Namespace.Path,
+				Name:       project.Name,
+				FullName:   project.PathWithNamespace,
+				CloneURL:   project.HTTPURLToRepo,
+				IsFork:     project.ForkedFromProject != nil,
+				IsArchived: project.Archived,
+			})
+		}
+	}
+	return repos, nil
+}
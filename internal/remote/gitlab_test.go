@@ -0,0 +1,66 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGitLabListReposRequestURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		userOrOrg string
+		wantPath  string
+		wantQuery url.Values
+	}{
+		{
+			name:      "membership listing",
+			userOrOrg: "",
+			wantPath:  "/api/v4/projects",
+			wantQuery: url.Values{"membership": {"true"}, "per_page": {"50"}, "page": {"1"}},
+		},
+		{
+			name:      "group listing",
+			userOrOrg: "mygroup",
+			wantPath:  "/api/v4/groups/mygroup/projects",
+			wantQuery: url.Values{"per_page": {"50"}, "page": {"1"}},
+		},
+		{
+			name:      "subgroup path is percent-encoded",
+			userOrOrg: "top/sub",
+			wantPath:  "/api/v4/groups/top%2Fsub/projects",
+			wantQuery: url.Values{"per_page": {"50"}, "page": {"1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRawPath string
+			var gotQuery url.Values
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRawPath = r.URL.EscapedPath()
+				gotQuery = r.URL.Query()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte("[]"))
+			}))
+			defer srv.Close()
+
+			r := &gitlabRemote{name: "gitlab", cfg: Config{BaseURL: srv.URL, UserOrOrg: tt.userOrOrg}}
+			if _, err := r.ListRepos(context.Background()); err != nil {
+				t.Fatalf("ListRepos() error = %v", err)
+			}
+
+			if gotRawPath != tt.wantPath {
+				t.Errorf("request path = %q, want %q", gotRawPath, tt.wantPath)
+			}
+			for k, want := range tt.wantQuery {
+				if got := gotQuery[k]; len(got) != 1 || got[0] != want[0] {
+					t.Errorf("query[%q] = %v, want %v", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var httpClient = &http.Client{}
+
+// getJSON issues an authenticated GET against url and decodes the JSON body
+// into out. headers are applied on top of the request (used for the
+// provider-specific auth scheme).
+func getJSON(ctx context.Context, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s: %s", resp.Status, url, body)
+	}
+
+	return json.Unmarshal(body, out)
+}
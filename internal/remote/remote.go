@@ -0,0 +1,95 @@
+// Package remote abstracts over the different code hosts github_repo_sync
+// can enumerate repositories from, so the sync loop and worker pool don't
+// need to know whether a repo came from GitHub, Gitea, GitLab, a Bitbucket
+// Server instance or Gerrit.
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// RemoteRepo is a normalized repository discovered on a Remote.
+type RemoteRepo struct {
+	Owner      string
+	Name       string
+	FullName   string
+	CloneURL   string
+	IsFork     bool
+	IsArchived bool
+}
+
+// Remote lists repositories on one code host and knows how to authenticate
+// git operations (clone/fetch/pull) against it.
+type Remote interface {
+	// Name identifies the remote for logging and for namespacing repo
+	// paths on disk, e.g. "github", "gitea-internal".
+	Name() string
+	ListRepos(ctx context.Context) ([]RemoteRepo, error)
+	AuthMethod() transport.AuthMethod
+}
+
+// RateLimiter is implemented by remotes whose backing API exposes a rate
+// limit worth reporting (currently only GitHub). Callers type-assert for
+// it rather than requiring it on every Remote.
+type RateLimiter interface {
+	RateLimitRemaining(ctx context.Context) (int, error)
+}
+
+// DuplicateReporter is implemented by remotes that merge multiple
+// enumeration sources and can report how many repos were seen more than
+// once (currently only GitHub, via its user/org/starred/gist listings).
+// Callers type-assert for it rather than requiring it on every Remote.
+type DuplicateReporter interface {
+	SkippedDuplicates() int
+}
+
+// Config describes one entry under the `remotes:` config list.
+type Config struct {
+	Type         string   `yaml:"type"`
+	Name         string   `yaml:"name"`
+	BaseURL      string   `yaml:"base_url"`
+	Token        string   `yaml:"token"`
+	UserOrOrg    string   `yaml:"user_or_org"`
+	IncludeRepos []string `yaml:"include_repos"`
+	ExcludeRepos []string `yaml:"exclude_repos"`
+
+	// IncludeOrgs, IncludeStarred and IncludeGists only apply to the
+	// "github" remote type; other types ignore them.
+	IncludeOrgs    []string `yaml:"include_orgs"`
+	IncludeStarred bool     `yaml:"include_starred"`
+	IncludeGists   bool     `yaml:"include_gists"`
+}
+
+// New builds the Remote for cfg based on its Type.
+func New(cfg Config) (Remote, error) {
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+
+	switch cfg.Type {
+	case "github", "":
+		return newGitHubRemote(name, cfg), nil
+	case "gitea":
+		return newGiteaRemote(name, cfg), nil
+	case "gitlab":
+		return newGitLabRemote(name, cfg), nil
+	case "bitbucket":
+		return newBitbucketRemote(name, cfg), nil
+	case "gerrit":
+		return newGerritRemote(name, cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown remote type %q", cfg.Type)
+	}
+}
+
+func basicAuth(username, token string) transport.AuthMethod {
+	return &githttp.BasicAuth{
+		Username: username,
+		Password: token,
+	}
+}